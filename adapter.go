@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	coreLog "log"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StdLogger returns a *log.Logger whose output is written through Info,
+// so code that only knows the standard library logger can still ship to
+// this package without being rewritten.
+func StdLogger(tags []string) *coreLog.Logger {
+	return coreLog.New(&stdWriter{tags: tags}, "", 0)
+}
+
+type stdWriter struct {
+	tags []string
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	Info(w.tags, string(bytes.TrimRight(p, "\n")), nil)
+	return len(p), nil
+}
+
+// logrusSeverity maps logrus levels to this package's Severity.
+var logrusSeverity = map[logrus.Level]Severity{
+	logrus.PanicLevel: CriticalSeverity,
+	logrus.FatalLevel: CriticalSeverity,
+	logrus.ErrorLevel: ErrorSeverity,
+	logrus.WarnLevel:  WarningSeverity,
+	logrus.InfoLevel:  InfoSeverity,
+	logrus.DebugLevel: DebugSeverity,
+	logrus.TraceLevel: DebugSeverity,
+}
+
+// LogrusHook forwards logrus entries to this package, so it can be
+// installed with logrus.AddHook to ship logs through the Billes client
+// without replacing an existing logrus setup.
+type LogrusHook struct {
+	Tags []string
+}
+
+func (h LogrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h LogrusHook) Fire(e *logrus.Entry) error {
+	sev, ok := logrusSeverity[e.Level]
+	if !ok {
+		sev = InfoSeverity
+	}
+
+	var data interface{}
+	if len(e.Data) > 0 {
+		m := make(map[string]interface{}, len(e.Data))
+		for k, v := range e.Data {
+			m[k] = v
+		}
+		data = m
+	}
+
+	return log(newEntry(sev, h.Tags, e.Message, data))
+}
+
+// Service is a minimal façade other libraries' constructors can accept
+// instead of importing a concrete logging package directly - mirroring
+// cloudflared's logger.Service pattern - so they can use the Billes
+// client without depending on it by name.
+type Service interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+type service struct {
+	l    *Logger
+	tags []string
+}
+
+// NewService wraps l as a Service. l may be nil, in which case the
+// package's default logger (the one behind the package-level functions)
+// is used.
+func NewService(l *Logger, tags []string) Service {
+	if l == nil {
+		l = defaultLogger
+	}
+	return &service{l: l, tags: tags}
+}
+
+func (s *service) Debugf(format string, args ...interface{}) {
+	s.l.Debug(s.tags, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *service) Infof(format string, args ...interface{}) {
+	s.l.Info(s.tags, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *service) Warnf(format string, args ...interface{}) {
+	s.l.Warning(s.tags, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *service) Errorf(format string, args ...interface{}) {
+	s.l.Error(s.tags, fmt.Sprintf(format, args...), nil)
+}
+
+func (s *service) Fatalf(format string, args ...interface{}) {
+	s.l.Fatal(s.tags, fmt.Sprintf(format, args...), nil)
+}
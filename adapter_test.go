@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// captureSink is a Sink that records every entry it's given, used so
+// adapter tests can assert on what actually got logged without touching
+// the network.
+type captureSink struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (s *captureSink) Write(e logEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+func (s *captureSink) Flush() error { return nil }
+func (s *captureSink) Close() error { return nil }
+
+func (s *captureSink) last() logEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[len(s.entries)-1]
+}
+
+// withCaptureLogr points the package-level logr at a logger configured
+// with sink as its only sink, and restores the previous logr on cleanup.
+func withCaptureLogr(t *testing.T, sink Sink) {
+	t.Helper()
+	prev := logr
+	logr = &logger{options: Options{
+		System: "test",
+		Sinks:  []SinkConfig{{Sink: sink, Level: DebugSeverity}},
+	}}
+	t.Cleanup(func() { logr = prev })
+}
+
+func TestLogrusSeverityMapping(t *testing.T) {
+	for _, level := range logrus.AllLevels {
+		if _, ok := logrusSeverity[level]; !ok {
+			t.Errorf("logrusSeverity has no mapping for logrus level %v", level)
+		}
+	}
+}
+
+func TestLogrusHookFire(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	hook := LogrusHook{Tags: []string{"svc"}}
+	data := logrus.Fields{"key": "value"}
+	entry := &logrus.Entry{Level: logrus.ErrorLevel, Message: "boom", Data: data}
+
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+
+	got := sink.last()
+	if got.Severity != ErrorSeverity {
+		t.Errorf("Severity = %v, want %v", got.Severity, ErrorSeverity)
+	}
+	if got.Message != "boom" {
+		t.Errorf("Message = %q, want %q", got.Message, "boom")
+	}
+
+	gotData, ok := got.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Data is %T, want map[string]interface{}", got.Data)
+	}
+
+	// Mutating the logrus entry's Data afterwards must not affect what
+	// was already logged.
+	data["key"] = "mutated"
+	if gotData["key"] != "value" {
+		t.Errorf("Fire aliased logrus.Entry.Data; got[key] = %v after mutation, want %q", gotData["key"], "value")
+	}
+}
+
+func TestLogrusHookFireNoData(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	hook := LogrusHook{}
+	entry := &logrus.Entry{Level: logrus.InfoLevel, Message: "hi"}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+	if got := sink.last(); got.Data != nil {
+		t.Errorf("Data = %v, want nil for an entry with no fields", got.Data)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	std := StdLogger([]string{"stdlib"})
+	std.Print("hello world")
+
+	got := sink.last()
+	if got.Severity != InfoSeverity {
+		t.Errorf("Severity = %v, want %v", got.Severity, InfoSeverity)
+	}
+	if got.Message != "hello world" {
+		t.Errorf("Message = %q, want %q", got.Message, "hello world")
+	}
+}
+
+func TestServiceFormatsAndMapsSeverity(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	svc := NewService(nil, []string{"svc"})
+	svc.Errorf("failed: %s", "reason")
+
+	got := sink.last()
+	if got.Severity != ErrorSeverity {
+		t.Errorf("Severity = %v, want %v", got.Severity, ErrorSeverity)
+	}
+	want := fmt.Sprintf("failed: %s", "reason")
+	if got.Message != want {
+		t.Errorf("Message = %q, want %q", got.Message, want)
+	}
+}
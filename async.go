@@ -0,0 +1,304 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBufferSize    = 1000
+	defaultBatchSize     = 50
+	defaultBatchInterval = 5
+)
+
+// dispatcher batches log entries pushed from Critical/Error/Info/... and
+// ships them to the remote host in the background. Entries that can't be
+// delivered (postLog already retries with backoff and trips the circuit
+// breaker on repeated failure) are spooled to disk (when
+// Options.SpoolPath is set) so they survive process restarts. The spool
+// is replayed only when a dispatcher starts (at Init); entries spooled
+// while one is running are not re-sent until the next start.
+type dispatcher struct {
+	buffer  chan logEntry
+	flush   chan chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+	sendWG  sync.WaitGroup
+	spool   *os.File
+	spoolMu sync.Mutex
+}
+
+func newDispatcher(o Options) *dispatcher {
+	bufferSize := o.BufferSize
+	if bufferSize < 1 {
+		bufferSize = defaultBufferSize
+	}
+
+	d := &dispatcher{
+		buffer: make(chan logEntry, bufferSize),
+		flush:  make(chan chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	if o.SpoolPath != "" {
+		f, err := os.OpenFile(o.SpoolPath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+		if err != nil {
+			writeLocalLog(newEntry(WarningSeverity, logTags, "Could not open spool file, overflow will be dropped", nil))
+		} else {
+			d.spool = f
+		}
+	}
+
+	d.wg.Add(1)
+	go d.run(o)
+
+	return d
+}
+
+// tryPush enqueues an entry for async delivery without blocking, reporting
+// whether the buffer had room for it.
+func (d *dispatcher) tryPush(e logEntry) bool {
+	select {
+	case d.buffer <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// push enqueues an entry for async delivery. It never blocks; if the
+// buffer is full the entry is written to the local log instead.
+func (d *dispatcher) push(e logEntry) {
+	if !d.tryPush(e) {
+		writeLocalLog(e)
+	}
+}
+
+func (d *dispatcher) run(o Options) {
+	defer d.wg.Done()
+
+	batchSize := o.BatchSize
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+	batchInterval := o.BatchInterval
+	if batchInterval < 1 {
+		batchInterval = defaultBatchInterval
+	}
+
+	d.replaySpool()
+
+	ticker := time.NewTicker(time.Duration(batchInterval) * time.Second)
+	defer ticker.Stop()
+
+	batch := make([]logEntry, 0, batchSize)
+
+	for {
+		select {
+		case e := <-d.buffer:
+			batch = append(batch, e)
+			if len(batch) >= batchSize {
+				d.sendBatch(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				d.sendBatch(batch)
+				batch = batch[:0]
+			}
+		case reply := <-d.flush:
+			d.drainBuffer(&batch, batchSize)
+			if len(batch) > 0 {
+				d.sendBatch(batch)
+				batch = batch[:0]
+			}
+			d.sendWG.Wait()
+			close(reply)
+		case <-d.done:
+			d.drainBuffer(&batch, batchSize)
+			if len(batch) > 0 {
+				d.sendBatch(batch)
+			}
+			d.sendWG.Wait()
+			return
+		}
+	}
+}
+
+// drainBuffer pulls any entries currently sitting in the channel without
+// blocking, so Flush/Shutdown ship everything that's already queued. Full
+// batches of batchSize are sent as they fill; the remainder is left in batch.
+func (d *dispatcher) drainBuffer(batch *[]logEntry, batchSize int) {
+	for {
+		select {
+		case e := <-d.buffer:
+			*batch = append(*batch, e)
+			if len(*batch) >= batchSize {
+				d.sendBatch(*batch)
+				*batch = (*batch)[:0]
+			}
+		default:
+			return
+		}
+	}
+}
+
+// sendBatch hands batch off to postLog in the background so a slow
+// retry/backoff cycle doesn't stall the dispatcher's single goroutine and
+// starve d.buffer drainage during an outage. flushAndWait/shutdown join
+// on sendWG so a caller-visible flush still waits for delivery to finish.
+func (d *dispatcher) sendBatch(batch []logEntry) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		for _, e := range batch {
+			writeLocalLog(e)
+		}
+		return
+	}
+
+	// The caller reuses batch's backing array, so keep our own copy for
+	// the failure path below.
+	batch = append([]logEntry(nil), batch...)
+
+	d.sendWG.Add(1)
+	go func() {
+		defer d.sendWG.Done()
+		if err := postLog(body); err != nil {
+			for _, e := range batch {
+				writeLocalLog(e)
+			}
+			d.spoolBatch(batch)
+		}
+	}()
+}
+
+func (d *dispatcher) spoolBatch(batch []logEntry) {
+	if d.spool == nil {
+		return
+	}
+
+	d.spoolMu.Lock()
+	defer d.spoolMu.Unlock()
+
+	for _, e := range batch {
+		body, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		d.spool.Write(append(body, '\n'))
+	}
+}
+
+// replaySpool reads any entries left over from a previous process and
+// pushes them back onto the buffer before normal delivery resumes. Only
+// entries that actually fit in the buffer are cleared from the spool
+// file; anything tryPush rejects (e.g. a backlog bigger than BufferSize)
+// stays spooled so a second crash before it's delivered doesn't lose it.
+func (d *dispatcher) replaySpool() {
+	if d.spool == nil {
+		return
+	}
+
+	if _, err := d.spool.Seek(0, 0); err != nil {
+		return
+	}
+
+	scanner := bufio.NewScanner(d.spool)
+	var replayed []logEntry
+	for scanner.Scan() {
+		var e logEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			replayed = append(replayed, e)
+		}
+	}
+
+	var leftover []logEntry
+	for _, e := range replayed {
+		if !d.tryPush(e) {
+			leftover = append(leftover, e)
+		}
+	}
+
+	d.rewriteSpool(leftover)
+}
+
+// rewriteSpool replaces the spool file's contents with entries. Safe to
+// call with a subset of what's already on disk since it always truncates
+// before writing.
+func (d *dispatcher) rewriteSpool(entries []logEntry) {
+	d.spoolMu.Lock()
+	defer d.spoolMu.Unlock()
+
+	d.spool.Truncate(0)
+	d.spool.Seek(0, 0)
+
+	for _, e := range entries {
+		body, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		d.spool.Write(append(body, '\n'))
+	}
+}
+
+// flushAndWait triggers an immediate flush of the pending batch and blocks
+// until it completes or ctx is done.
+func (d *dispatcher) flushAndWait(ctx context.Context) error {
+	reply := make(chan struct{})
+	select {
+	case d.flush <- reply:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-reply:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *dispatcher) shutdown(ctx context.Context) error {
+	close(d.done)
+
+	stopped := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		if d.spool != nil {
+			d.spool.Close()
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Flush blocks until all currently buffered log entries have been sent
+// (or spooled), or ctx is done. It is a no-op when Options.Sync is true.
+func Flush(ctx context.Context) error {
+	if logr == nil || logr.dispatcher == nil {
+		return nil
+	}
+	return logr.dispatcher.flushAndWait(ctx)
+}
+
+// Shutdown flushes any pending log entries and stops the background
+// delivery goroutine. Applications should call Shutdown before exiting so
+// buffered logs aren't lost; Fatal flushes the dispatcher itself before
+// calling os.Exit.
+func Shutdown(ctx context.Context) error {
+	if logr == nil || logr.dispatcher == nil {
+		return nil
+	}
+	return logr.dispatcher.shutdown(ctx)
+}
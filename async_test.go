@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func newTestDispatcher(t *testing.T, bufferSize int) *dispatcher {
+	t.Helper()
+	return &dispatcher{buffer: make(chan logEntry, bufferSize)}
+}
+
+func TestDispatcherTryPush(t *testing.T) {
+	d := newTestDispatcher(t, 1)
+
+	if !d.tryPush(logEntry{Message: "first"}) {
+		t.Fatal("tryPush on an empty buffer should succeed")
+	}
+	if d.tryPush(logEntry{Message: "second"}) {
+		t.Fatal("tryPush on a full buffer should report false, not block")
+	}
+}
+
+func TestDispatcherPushFallsBackToLocalWhenFull(t *testing.T) {
+	d := newTestDispatcher(t, 1)
+	d.buffer <- logEntry{Message: "filler"}
+
+	// push must not block even though the buffer is full.
+	d.push(logEntry{Message: "overflow"})
+}
+
+func TestDispatcherSpoolRoundTrip(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "spool")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	d := &dispatcher{buffer: make(chan logEntry, 10), spool: f}
+
+	batch := []logEntry{
+		{Message: "one", Severity: InfoSeverity},
+		{Message: "two", Severity: ErrorSeverity},
+	}
+	d.spoolBatch(batch)
+
+	d.replaySpool()
+
+	var replayed []logEntry
+	for len(d.buffer) > 0 {
+		replayed = append(replayed, <-d.buffer)
+	}
+	if len(replayed) != len(batch) {
+		t.Fatalf("replayed %d entries, want %d", len(replayed), len(batch))
+	}
+	for i, e := range replayed {
+		if e.Message != batch[i].Message {
+			t.Errorf("replayed[%d].Message = %q, want %q", i, e.Message, batch[i].Message)
+		}
+	}
+
+	// The spool should have been cleared since everything fit in the buffer.
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("spool file size = %d, want 0 after a full replay", info.Size())
+	}
+}
+
+func TestDispatcherReplaySpoolKeepsLeftoversWhenBufferFull(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "spool")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	d := &dispatcher{buffer: make(chan logEntry, 1), spool: f}
+
+	batch := []logEntry{
+		{Message: "one"},
+		{Message: "two"},
+	}
+	d.spoolBatch(batch)
+
+	d.replaySpool()
+
+	// Only one entry fits in the buffer; "two" must remain on disk instead
+	// of being silently dropped.
+	if len(d.buffer) != 1 {
+		t.Fatalf("buffer has %d entries, want 1", len(d.buffer))
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Size() == 0 {
+		t.Error("spool file is empty, want the entry that didn't fit to remain spooled")
+	}
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultMaxAttempts     = 3
+	defaultInitialBackoff  = 500 * time.Millisecond
+	defaultMaxPostBackoff  = 10 * time.Second
+	defaultCircuitThresh   = 5
+	defaultCircuitCooldown = 30 * time.Second
+)
+
+// RetryPolicy configures how postLog retries a failed delivery attempt
+// before giving up and recording a circuit breaker failure.
+type RetryPolicy struct {
+	MaxAttempts    int           // Default 3
+	InitialBackoff time.Duration // Default 500ms
+	MaxBackoff     time.Duration // Default 10s
+}
+
+var errCircuitOpen = errors.New("circuit breaker open, short-circuiting to local log")
+
+// circuitBreaker opens after a run of consecutive postLog failures and
+// stays open for a cooldown window, during which postLog is
+// short-circuited without dialing the host.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	consecutiveFails int
+	openUntil        time.Time
+	threshold        int
+	cooldown         time.Duration
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		threshold = defaultCircuitThresh
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFails = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+func (b *circuitBreaker) state() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if time.Now().Before(b.openUntil) {
+		return "open"
+	}
+	return "closed"
+}
+
+// DeliveryStats summarizes the health of the remote delivery path.
+type DeliveryStats struct {
+	Attempts  int64
+	Successes int64
+	Drops     int64
+	Circuit   string
+}
+
+var deliveryStats struct {
+	attempts  int64
+	successes int64
+	drops     int64
+}
+
+var breaker = newCircuitBreaker(defaultCircuitThresh, defaultCircuitCooldown)
+
+var retryPolicy RetryPolicy
+
+// Stats returns delivery health counters (attempts, successes, drops) and
+// the current circuit breaker state so operators can monitor remote
+// delivery health.
+func Stats() DeliveryStats {
+	return DeliveryStats{
+		Attempts:  atomic.LoadInt64(&deliveryStats.attempts),
+		Successes: atomic.LoadInt64(&deliveryStats.successes),
+		Drops:     atomic.LoadInt64(&deliveryStats.drops),
+		Circuit:   breaker.state(),
+	}
+}
+
+// postLog retries doPost with exponential backoff and jitter, and
+// short-circuits to a local log (without dialing) while the circuit
+// breaker is open after too many consecutive failures.
+func postLog(body []byte) error {
+	if !breaker.allow() {
+		atomic.AddInt64(&deliveryStats.drops, 1)
+		writeLocalLog(newEntry(WarningSeverity, logTags, "Circuit breaker open, dropping remote log", nil))
+		return errCircuitOpen
+	}
+
+	maxAttempts := retryPolicy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
+	backoff := retryPolicy.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+	maxBackoff := retryPolicy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxPostBackoff
+	}
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		atomic.AddInt64(&deliveryStats.attempts, 1)
+
+		err = doPost(body)
+		if err == nil {
+			atomic.AddInt64(&deliveryStats.successes, 1)
+			breaker.recordSuccess()
+			return nil
+		}
+
+		if attempt < maxAttempts-1 {
+			jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+			time.Sleep(backoff + jitter)
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+
+	breaker.recordFailure()
+	atomic.AddInt64(&deliveryStats.drops, 1)
+	return err
+}
@@ -0,0 +1,47 @@
+package logger
+
+import "testing"
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, 0)
+
+	for i := 0; i < 2; i++ {
+		b.recordFailure()
+		if !b.allow() {
+			t.Fatalf("breaker opened after %d failures, want threshold 3", i+1)
+		}
+	}
+
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("breaker should be open after reaching the failure threshold")
+	}
+	if got := b.state(); got != "open" {
+		t.Errorf("state() = %q, want %q", got, "open")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, 0)
+
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+
+	if !b.allow() {
+		t.Fatal("breaker opened after only one failure since the last success")
+	}
+	if got := b.state(); got != "closed" {
+		t.Errorf("state() = %q, want %q", got, "closed")
+	}
+}
+
+func TestCircuitBreakerDefaultsThresholdAndCooldown(t *testing.T) {
+	b := newCircuitBreaker(0, 0)
+	if b.threshold != defaultCircuitThresh {
+		t.Errorf("threshold = %d, want default %d", b.threshold, defaultCircuitThresh)
+	}
+	if b.cooldown != defaultCircuitCooldown {
+		t.Errorf("cooldown = %v, want default %v", b.cooldown, defaultCircuitCooldown)
+	}
+}
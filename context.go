@@ -0,0 +1,152 @@
+package logger
+
+import "context"
+
+// Logger is a contextual logger that carries a fixed set of key/value
+// fields which are merged under a "context" object in every entry it
+// emits. The package-level Critical/Debug/Error/Fatal/Info/Warning
+// functions are shorthand for calling those methods on a Logger with no
+// fields.
+type Logger struct {
+	fields []interface{}
+}
+
+var defaultLogger = &Logger{}
+
+// New returns a Logger that merges fields into every entry it emits.
+// fields is a flat list of alternating key/value pairs, e.g.
+// New("request_id", id, "user", u).
+func New(fields ...interface{}) *Logger {
+	return &Logger{fields: fields}
+}
+
+// With returns a new Logger with fields merged on top of l's existing
+// ones. l is left unmodified.
+func (l *Logger) With(fields ...interface{}) *Logger {
+	merged := make([]interface{}, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	return &Logger{fields: merged}
+}
+
+type contextKey string
+
+const (
+	traceIDKey contextKey = "trace_id"
+	spanIDKey  contextKey = "span_id"
+)
+
+// ContextWithTraceID attaches a trace id to ctx so a later WithContext
+// call picks it up as a field.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithSpanID attaches a span id to ctx so a later WithContext call
+// picks it up as a field.
+func ContextWithSpanID(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, spanIDKey, spanID)
+}
+
+// WithContext extracts well-known keys (trace id, span id) from ctx and
+// merges them in as fields, same as With.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	var fields []interface{}
+	if v, ok := ctx.Value(traceIDKey).(string); ok {
+		fields = append(fields, "trace_id", v)
+	}
+	if v, ok := ctx.Value(spanIDKey).(string); ok {
+		fields = append(fields, "span_id", v)
+	}
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
+// Critical creates a log for critical error messages, merging l's fields
+// in under "context".
+func (l *Logger) Critical(tags []string, message string, data interface{}) {
+	if !shouldLog(CriticalSeverity, tags) {
+		return
+	}
+	log(l.newEntry(CriticalSeverity, tags, message, data))
+}
+
+// Debug creates a log for debug messages, merging l's fields in under
+// "context". It's a cheap no-op when Options.Level is above Debug or a
+// sampling rule drops this entry.
+func (l *Logger) Debug(tags []string, message string, data interface{}) {
+	if !shouldLog(DebugSeverity, tags) {
+		return
+	}
+	log(l.newEntry(DebugSeverity, tags, message, data))
+}
+
+// Error creates a log for error messages, merging l's fields in under
+// "context".
+func (l *Logger) Error(tags []string, message string, data interface{}) {
+	if !shouldLog(ErrorSeverity, tags) {
+		return
+	}
+	log(l.newEntry(ErrorSeverity, tags, message, data))
+}
+
+// Fatal creates a log for critical error messages, merging l's fields in
+// under "context", and shuts down the server.
+func (l *Logger) Fatal(tags []string, message string, data interface{}) {
+	fatal(l.newEntry(CriticalSeverity, tags, message, data))
+}
+
+// Info creates a log for informational messages, merging l's fields in
+// under "context". It's a cheap no-op when Options.Level is above Info or
+// a sampling rule drops this entry.
+func (l *Logger) Info(tags []string, message string, data interface{}) {
+	if !shouldLog(InfoSeverity, tags) {
+		return
+	}
+	log(l.newEntry(InfoSeverity, tags, message, data))
+}
+
+// Warning creates a log for warning messages, merging l's fields in
+// under "context".
+func (l *Logger) Warning(tags []string, message string, data interface{}) {
+	if !shouldLog(WarningSeverity, tags) {
+		return
+	}
+	log(l.newEntry(WarningSeverity, tags, message, data))
+}
+
+// shouldLog reports whether an entry at sev with tags should be built and
+// logged at all, consulting Options.Level and Options.Sample. Fatal skips
+// this check since it always has to run os.Exit regardless.
+func shouldLog(sev Severity, tags []string) bool {
+	if logr == nil {
+		// newEntry will fatal with a clear "instantiate the logger" error.
+		return true
+	}
+	if !sev.allows(logr.options.Level) {
+		return false
+	}
+	return sampleAllows(sev, tags)
+}
+
+func (l *Logger) newEntry(sev Severity, tags []string, message string, data interface{}) logEntry {
+	e := newEntry(sev, tags, message, data)
+	if len(l.fields) > 0 {
+		e.Context = fieldsToMap(l.fields)
+	}
+	return e
+}
+
+func fieldsToMap(fields []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			continue
+		}
+		m[key] = fields[i+1]
+	}
+	return m
+}
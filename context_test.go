@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFieldsToMap(t *testing.T) {
+	got := fieldsToMap([]interface{}{"a", 1, "b", "two", "dangling"})
+	want := map[string]interface{}{"a": 1, "b": "two"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fieldsToMap = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoggerWithMergesFieldsWithoutMutatingParent(t *testing.T) {
+	base := New("service", "api")
+	child := base.With("request_id", "123")
+
+	if len(base.fields) != 2 {
+		t.Errorf("base.fields mutated by With: %#v", base.fields)
+	}
+
+	got := fieldsToMap(child.fields)
+	want := map[string]interface{}{"service": "api", "request_id": "123"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("child fields = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoggerWithContextMergesTraceAndSpanID(t *testing.T) {
+	ctx := ContextWithTraceID(context.Background(), "trace-1")
+	ctx = ContextWithSpanID(ctx, "span-1")
+
+	l := New().WithContext(ctx)
+	got := fieldsToMap(l.fields)
+	want := map[string]interface{}{"trace_id": "trace-1", "span_id": "span-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoggerWithContextNoKeysReturnsSameLogger(t *testing.T) {
+	l := New("service", "api")
+	got := l.WithContext(context.Background())
+	if got != l {
+		t.Error("WithContext with no well-known keys should return the same *Logger")
+	}
+}
+
+func TestLoggerEmitsFieldsUnderContext(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	New("request_id", "abc").Info([]string{"tag"}, "hello", nil)
+
+	got := sink.last()
+	want := map[string]interface{}{"request_id": "abc"}
+	if !reflect.DeepEqual(got.Context, want) {
+		t.Errorf("Context = %#v, want %#v", got.Context, want)
+	}
+}
+
+func TestLoggerWithNoFieldsOmitsContext(t *testing.T) {
+	sink := &captureSink{}
+	withCaptureLogr(t, sink)
+
+	New().Info([]string{"tag"}, "hello", nil)
+
+	if got := sink.last(); got.Context != nil {
+		t.Errorf("Context = %#v, want nil when the logger carries no fields", got.Context)
+	}
+}
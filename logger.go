@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,36 +12,49 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
-type severity string
+type Severity string
 
 const (
-	criticalSeverity severity = "CRITICAL"
-	debugSeverity    severity = "DEBUG"
-	errorSeverity    severity = "ERROR"
-	infoSeverity     severity = "INFO"
-	warningSeverity  severity = "WARNING"
+	CriticalSeverity Severity = "CRITICAL"
+	DebugSeverity    Severity = "DEBUG"
+	ErrorSeverity    Severity = "ERROR"
+	InfoSeverity     Severity = "INFO"
+	WarningSeverity  Severity = "WARNING"
 )
 
 type logEntry struct {
-	Severity severity    `json:"severity"`
-	Tags     []string    `json:"tags"`
-	Message  string      `json:"message"`
-	Data     interface{} `json:"data"`
+	Severity Severity               `json:"severity"`
+	Tags     []string               `json:"tags"`
+	Message  string                 `json:"message"`
+	Data     interface{}            `json:"data"`
+	Context  map[string]interface{} `json:"context,omitempty"`
 }
 
 // Options is the config that is used for bootstrapping the logger.
 // Default is posting logs to remote server but omitting host will
 // write local logs instead.
 type Options struct {
-	Host    string `json:"host"`    // When omitting, logs will be written locally
-	System  string `json:"system"`  // Required
-	Token   string `json:"token"`   // Required if Host is set
-	Local   bool   `json:"local"`   // Default false - If you want to force local logs in addition to the remote ones
-	Timeout int    `json:"timeout"` // Default 10 - How long communication with server is allowed to take before giving up and writing a local log
+	Host             string                `json:"host"`          // When omitting, logs will be written locally
+	System           string                `json:"system"`        // Required
+	Token            string                `json:"token"`         // Required if Host is set
+	Local            bool                  `json:"local"`         // Default false - If you want to force local logs in addition to the remote ones
+	Timeout          int                   `json:"timeout"`       // Default 10 - How long (in seconds) each POST attempt to the server may take before it counts as failed and is retried per Retry
+	Sync             bool                  `json:"sync"`          // Default false - If true, log calls block and POST synchronously (legacy behavior)
+	SpoolPath        string                `json:"spoolPath"`     // Optional - file used to persist entries that couldn't be delivered so they survive a restart
+	BufferSize       int                   `json:"bufferSize"`    // Default 1000 - size of the in-memory ring buffer log calls push into
+	BatchSize        int                   `json:"batchSize"`     // Default 50 - max entries per batched POST
+	BatchInterval    int                   `json:"batchInterval"` // Default 5 - seconds between batch flushes
+	Sinks            []SinkConfig          `json:"-"`             // Optional - fan a log call out to several destinations, each with its own minimum severity. When unset, falls back to the Host/Local behavior above.
+	Level            Severity              `json:"level"`         // Optional - minimum severity to log at all; Debug/Info calls below it are cheap no-ops. Default allows every severity.
+	Sample           map[string]SampleRule `json:"-"`             // Optional - per-tag or per-severity sampling (key is a tag or a Severity string) so high-volume sources don't overwhelm the remote endpoint.
+	Retry            RetryPolicy           `json:"-"`             // Optional - retry/backoff policy around postLog. Default 3 attempts, 500ms initial backoff, 10s max.
+	CircuitThreshold int                   `json:"-"`             // Default 5 - consecutive postLog failures before the circuit breaker opens
+	CircuitCooldown  time.Duration         `json:"-"`             // Default 30s - how long the circuit breaker stays open before allowing another attempt
 }
 
 type logger struct {
-	options Options
+	options    Options
+	dispatcher *dispatcher
 }
 
 const format = "2006-01-02 15:04:05"
@@ -62,9 +76,16 @@ func Init(o Options) error {
 		return err
 	}
 
-	logr = &logger{o}
+	logr = &logger{options: o}
 
-	if o.Host == "" {
+	retryPolicy = o.Retry
+	breaker = newCircuitBreaker(o.CircuitThreshold, o.CircuitCooldown)
+
+	if !o.Sync {
+		logr.dispatcher = newDispatcher(o)
+	}
+
+	if o.Host == "" && len(o.Sinks) == 0 {
 		Warning(logTags, "Host is not set", nil)
 	}
 
@@ -72,78 +93,141 @@ func Init(o Options) error {
 }
 
 // Critical creates a log for critical error messages.
-// Is synchronous and if you need concurrency run it as a goroutine.
+// Unless Options.Sync is set, the entry is handed off to the background
+// dispatcher and this call returns immediately.
 func Critical(tags []string, message string, data interface{}) {
-	log(newEntry(criticalSeverity, tags, message, data))
+	defaultLogger.Critical(tags, message, data)
 }
 
 // Debug creates a log for debug messages.
-// Is synchronous and if you need concurrency run it as a goroutine.
+// Unless Options.Sync is set, the entry is handed off to the background
+// dispatcher and this call returns immediately.
 func Debug(tags []string, message string, data interface{}) {
-	log(newEntry(debugSeverity, tags, message, data))
+	defaultLogger.Debug(tags, message, data)
 }
 
 // Error creates a log for error messages.
-// Is synchronous and if you need concurrency run it as a goroutine.
+// Unless Options.Sync is set, the entry is handed off to the background
+// dispatcher and this call returns immediately.
 func Error(tags []string, message string, data interface{}) {
-	log(newEntry(errorSeverity, tags, message, data))
+	defaultLogger.Error(tags, message, data)
 }
 
 // Fatal creates a log for critical error messages and shuts down the server.
-// Is synchronous and should not be ran concurrently as it would defeat the
-// purpose of being a fatal action.
+// It always delivers synchronously (draining the dispatcher first when one is
+// running) so the entry isn't lost to os.Exit, and should not be ran
+// concurrently as it would defeat the purpose of being a fatal action.
 func Fatal(tags []string, message string, data interface{}) {
-	e := newEntry(criticalSeverity, tags, message, data)
-	if err := log(e); err == nil && !logr.options.Local {
-		// If an error didnt occur here, it wont write a local log so we do it here
-		writeLocalLog(e)
-	}
-	os.Exit(1)
+	defaultLogger.Fatal(tags, message, data)
 }
 
 // Info creates a log for informational messages.
-// Is synchronous and if you need concurrency run it as a goroutine.
+// Unless Options.Sync is set, the entry is handed off to the background
+// dispatcher and this call returns immediately.
 func Info(tags []string, message string, data interface{}) {
-	log(newEntry(infoSeverity, tags, message, data))
+	defaultLogger.Info(tags, message, data)
 }
 
 // Warning creates a log for warning messages.
-// Is synchronous and if you need concurrency run it as a goroutine.
+// Unless Options.Sync is set, the entry is handed off to the background
+// dispatcher and this call returns immediately.
 func Warning(tags []string, message string, data interface{}) {
-	log(newEntry(warningSeverity, tags, message, data))
+	defaultLogger.Warning(tags, message, data)
+}
+
+func fatal(e logEntry) {
+	switch {
+	case len(logr.options.Sinks) > 0:
+		writeToSinks(e)
+	case logr.dispatcher != nil:
+		if logr.options.Local {
+			writeLocalLog(e)
+		}
+		logr.dispatcher.push(e)
+	default:
+		if err := log(e); err == nil && !logr.options.Local {
+			// If an error didnt occur here, it wont write a local log so we do it here
+			writeLocalLog(e)
+		}
+	}
+
+	if logr.dispatcher != nil {
+		logr.dispatcher.flushAndWait(context.Background())
+	}
+	os.Exit(1)
 }
 
-func newEntry(severity severity, tags []string, message string, data interface{}) logEntry {
+func newEntry(sev Severity, tags []string, message string, data interface{}) logEntry {
 	if logr == nil {
 		coreLog.Fatal("You need to instantiate the logger first")
 	}
 	return logEntry{
-		severity,
-		append([]string{logr.options.System}, tags...),
-		message,
-		data,
+		Severity: sev,
+		Tags:     append([]string{logr.options.System}, tags...),
+		Message:  message,
+		Data:     data,
 	}
 }
 
 func log(e logEntry) error {
+	if len(logr.options.Sinks) > 0 {
+		return writeToSinks(e)
+	}
+
+	if logr.options.Local {
+		writeLocalLog(e)
+	}
+
+	if logr.dispatcher != nil {
+		logr.dispatcher.push(e)
+		return nil
+	}
+
 	body, err := json.Marshal(e)
 	if err != nil {
 		writeLocalLog(e)
 		Error(logTags, fmt.Sprintf("Could not post to log due to \"data\" wasn't encodable - See local log"), "")
+		return nil
+	}
+
+	err = postLog(body)
+	if err != nil {
+		writeLocalLog(e)
 	}
+	return err
+}
+
+// writeToSinks fans e out to every configured sink whose minimum severity
+// it meets. An HTTPSink is routed through the background dispatcher (when
+// one is running) the same way the legacy Host path is, so it still gets
+// batching, backoff and spooling; every other sink writes synchronously.
+func writeToSinks(e logEntry) error {
+	var firstErr error
+
+	for _, sc := range logr.options.Sinks {
+		if !e.Severity.allows(sc.Level) {
+			continue
+		}
 
-	if err == nil {
-		err = postLog(body)
-		if err != nil || logr.options.Local {
+		if _, ok := sc.Sink.(HTTPSink); ok && logr.dispatcher != nil {
+			logr.dispatcher.push(e)
+			continue
+		}
+
+		if err := sc.Sink.Write(e); err != nil {
 			writeLocalLog(e)
+			if firstErr == nil {
+				firstErr = err
+			}
 		}
-		return err
 	}
 
-	return nil
+	return firstErr
 }
 
-func postLog(body []byte) error {
+// doPost makes a single attempt at POSTing body to the remote host.
+// Callers needing retries/circuit-breaking should go through postLog.
+func doPost(body []byte) error {
 	if logr.options.Host == "" {
 		return errors.New("Host is not set")
 	}
@@ -159,7 +243,7 @@ func postLog(body []byte) error {
 
 	err := client.DoTimeout(req, res, time.Duration(logr.options.Timeout)*time.Second)
 	if err != nil && logr.options.Host != "" {
-		entry := newEntry(warningSeverity, logTags, fmt.Sprintf("Failed while sending log entry request: %s", err.Error()), nil)
+		entry := newEntry(WarningSeverity, logTags, fmt.Sprintf("Failed while sending log entry request: %s", err.Error()), nil)
 		writeLocalLog(entry)
 	}
 	return err
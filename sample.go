@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// SampleRule expresses a sampling policy: log the first Burst entries
+// within each Interval, then only 1 in Every after that. Configure it
+// under Options.Sample, keyed by either a tag (e.g. "checkout") or a
+// Severity string (e.g. string(DebugSeverity)).
+type SampleRule struct {
+	Burst    int           // Default 0 - always let through the first Burst entries per interval
+	Every    int           // Default 1 - after Burst, let through 1 in Every
+	Interval time.Duration // Default time.Minute - window the counters reset on
+}
+
+type sampleCounter struct {
+	mu      sync.Mutex
+	resetAt time.Time
+	count   int
+}
+
+var sampleCounters sync.Map // key string -> *sampleCounter
+
+// sampleAllows consults Options.Sample for a rule matching one of tags or
+// sev, in that order, and reports whether this particular entry should
+// pass. It returns true when no rule matches.
+func sampleAllows(sev Severity, tags []string) bool {
+	if logr == nil || len(logr.options.Sample) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		if rule, ok := logr.options.Sample[tag]; ok {
+			return evalSampleRule(tag, rule)
+		}
+	}
+
+	if rule, ok := logr.options.Sample[string(sev)]; ok {
+		return evalSampleRule(string(sev), rule)
+	}
+
+	return true
+}
+
+func evalSampleRule(key string, rule SampleRule) bool {
+	interval := rule.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	every := rule.Every
+	if every < 1 {
+		every = 1
+	}
+
+	v, _ := sampleCounters.LoadOrStore(key, &sampleCounter{})
+	c := v.(*sampleCounter)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.resetAt.IsZero() || now.After(c.resetAt) {
+		c.resetAt = now.Add(interval)
+		c.count = 0
+	}
+
+	c.count++
+	if c.count <= rule.Burst {
+		return true
+	}
+
+	return (c.count-rule.Burst-1)%every == 0
+}
@@ -0,0 +1,86 @@
+package logger
+
+import "testing"
+
+func TestEvalSampleRuleBurstThenEvery(t *testing.T) {
+	rule := SampleRule{Burst: 2, Every: 3}
+	key := "test-burst-then-every"
+
+	want := []bool{true, true, true, false, false, true, false, false}
+	for i, w := range want {
+		if got := evalSampleRule(key, rule); got != w {
+			t.Errorf("call %d: evalSampleRule = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestEvalSampleRuleDefaultsEveryToOne(t *testing.T) {
+	rule := SampleRule{}
+	key := "test-default-every"
+
+	for i := 0; i < 3; i++ {
+		if !evalSampleRule(key, rule) {
+			t.Errorf("call %d: want every entry to pass with a zero-value Every", i+1)
+		}
+	}
+}
+
+func TestSampleAllowsNoRuleConfigured(t *testing.T) {
+	prev := logr
+	logr = &logger{}
+	t.Cleanup(func() { logr = prev })
+
+	if !sampleAllows(DebugSeverity, []string{"anything"}) {
+		t.Error("sampleAllows should default to true when Options.Sample is unset")
+	}
+}
+
+func TestSampleAllowsNilLogr(t *testing.T) {
+	prev := logr
+	logr = nil
+	t.Cleanup(func() { logr = prev })
+
+	if !sampleAllows(DebugSeverity, []string{"anything"}) {
+		t.Error("sampleAllows should default to true when logr is nil")
+	}
+}
+
+func TestSampleAllowsPrefersTagRuleOverSeverityRule(t *testing.T) {
+	prev := logr
+	logr = &logger{options: Options{Sample: map[string]SampleRule{
+		"checkout":           {Burst: 0, Every: 1},
+		string(InfoSeverity): {Burst: 0, Every: 1000},
+	}}}
+	t.Cleanup(func() { logr = prev })
+
+	// The tag rule (Every: 1, always pass) should win over the severity
+	// rule (Every: 1000, almost always drop) when both could match.
+	for i := 0; i < 3; i++ {
+		if !sampleAllows(InfoSeverity, []string{"checkout"}) {
+			t.Errorf("call %d: expected the tag rule to take priority and let this through", i+1)
+		}
+	}
+}
+
+func TestShouldLogRespectsLevelThreshold(t *testing.T) {
+	prev := logr
+	logr = &logger{options: Options{Level: WarningSeverity}}
+	t.Cleanup(func() { logr = prev })
+
+	if shouldLog(DebugSeverity, nil) {
+		t.Error("shouldLog(Debug) should be false when Options.Level is Warning")
+	}
+	if !shouldLog(ErrorSeverity, nil) {
+		t.Error("shouldLog(Error) should be true when Options.Level is Warning")
+	}
+}
+
+func TestShouldLogNilLogrDefaultsToTrue(t *testing.T) {
+	prev := logr
+	logr = nil
+	t.Cleanup(func() { logr = prev })
+
+	if !shouldLog(DebugSeverity, nil) {
+		t.Error("shouldLog should default to true when logr is nil, deferring the error to newEntry")
+	}
+}
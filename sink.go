@@ -0,0 +1,62 @@
+package logger
+
+import "encoding/json"
+
+// Sink is a log delivery destination. Init can be given any number of
+// sinks via Options.Sinks, each with its own minimum severity, so a
+// single log call can fan out to several destinations with different
+// thresholds instead of the all-or-nothing Host/Local split.
+type Sink interface {
+	Write(e logEntry) error
+	Flush() error
+	Close() error
+}
+
+// SinkConfig pairs a Sink with the minimum severity it should receive.
+type SinkConfig struct {
+	Sink  Sink
+	Level Severity
+}
+
+var severityRank = map[Severity]int{
+	DebugSeverity:    0,
+	InfoSeverity:     1,
+	WarningSeverity:  2,
+	ErrorSeverity:    3,
+	CriticalSeverity: 4,
+}
+
+// allows reports whether s meets the given minimum severity.
+func (s Severity) allows(min Severity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
+// ConsoleSink writes entries to stdout in the same format the package has
+// always used for local logs.
+type ConsoleSink struct{}
+
+func (ConsoleSink) Write(e logEntry) error {
+	writeLocalLog(e)
+	return nil
+}
+
+func (ConsoleSink) Flush() error { return nil }
+func (ConsoleSink) Close() error { return nil }
+
+// HTTPSink posts entries to the Billes log server - the client's original
+// (and still default, when Options.Sinks isn't set) delivery path. When
+// the background dispatcher is running, log calls route HTTPSink entries
+// through it instead of calling Write directly, so batching/backoff/spool
+// still apply.
+type HTTPSink struct{}
+
+func (HTTPSink) Write(e logEntry) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return postLog(body)
+}
+
+func (HTTPSink) Flush() error { return nil }
+func (HTTPSink) Close() error { return nil }
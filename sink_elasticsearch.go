@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ElasticsearchSink bulk-indexes entries into an Elasticsearch index
+// using the _bulk API.
+type ElasticsearchSink struct {
+	URL      string // e.g. "http://localhost:9200"
+	Index    string
+	Username string // Optional
+	Password string // Optional
+	Timeout  int    // Default 10 - seconds
+}
+
+type esBulkAction struct {
+	Index struct {
+		Index string `json:"_index"`
+	} `json:"index"`
+}
+
+func (s ElasticsearchSink) Write(e logEntry) error {
+	var buf bytes.Buffer
+
+	action := esBulkAction{}
+	action.Index.Index = s.Index
+	actionLine, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	entryLine, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(actionLine)
+	buf.WriteByte('\n')
+	buf.Write(entryLine)
+	buf.WriteByte('\n')
+
+	timeout := s.Timeout
+	if timeout < 1 {
+		timeout = 10
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	res := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(res)
+
+	req.SetRequestURI(fmt.Sprintf("%s/_bulk", s.URL))
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/x-ndjson")
+	if s.Username != "" {
+		req.Header.Set("Authorization", "Basic "+basicAuth(s.Username, s.Password))
+	}
+	req.SetBody(buf.Bytes())
+
+	client := &fasthttp.Client{}
+	if err := client.DoTimeout(req, res, time.Duration(timeout)*time.Second); err != nil {
+		return err
+	}
+
+	if res.StatusCode() >= 300 {
+		return fmt.Errorf("elasticsearch bulk index failed with status %d", res.StatusCode())
+	}
+
+	return nil
+}
+
+func (s ElasticsearchSink) Flush() error { return nil }
+func (s ElasticsearchSink) Close() error { return nil }
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
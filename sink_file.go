@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeBytes = 10 * 1024 * 1024
+	defaultMaxAge       = 24 * time.Hour
+)
+
+// RotatingFileSink writes entries as JSON lines to a file, rotating it
+// once it exceeds MaxSizeBytes or MaxAge.
+type RotatingFileSink struct {
+	Path         string
+	MaxSizeBytes int64         // Default 10MB
+	MaxAge       time.Duration // Default 24h
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingFileSink opens (creating if necessary) the file at path for
+// appending.
+func NewRotatingFileSink(path string) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{Path: path}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RotatingFileSink) open() error {
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+	s.opened = time.Now()
+	return nil
+}
+
+func (s *RotatingFileSink) Write(e logEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shouldRotate() {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	body = append(body, '\n')
+
+	n, err := s.file.Write(body)
+	s.size += int64(n)
+	return err
+}
+
+func (s *RotatingFileSink) shouldRotate() bool {
+	maxSize := s.MaxSizeBytes
+	if maxSize < 1 {
+		maxSize = defaultMaxSizeBytes
+	}
+	maxAge := s.MaxAge
+	if maxAge < 1 {
+		maxAge = defaultMaxAge
+	}
+	return s.size >= maxSize || time.Since(s.opened) >= maxAge
+}
+
+func (s *RotatingFileSink) rotate() error {
+	s.file.Close()
+
+	rotated := fmt.Sprintf("%s.%d", s.Path, time.Now().Unix())
+	if err := os.Rename(s.Path, rotated); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return s.open()
+}
+
+func (s *RotatingFileSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Sync()
+}
+
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SlackWebhookSink posts entries to a Slack incoming webhook. It's meant
+// to be registered with Level: WarningSeverity (or higher) so it doesn't
+// flood a channel with Debug/Info noise.
+type SlackWebhookSink struct {
+	WebhookURL string
+	Timeout    int // Default 10 - seconds
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func (s SlackWebhookSink) Write(e logEntry) error {
+	timeout := s.Timeout
+	if timeout < 1 {
+		timeout = 10
+	}
+
+	body, err := json.Marshal(slackPayload{
+		Text: fmt.Sprintf("*%s* %v - %s", e.Severity, e.Tags, e.Message),
+	})
+	if err != nil {
+		return err
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	res := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(res)
+
+	req.SetRequestURI(s.WebhookURL)
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	req.SetBody(body)
+
+	client := &fasthttp.Client{}
+	return client.DoTimeout(req, res, time.Duration(timeout)*time.Second)
+}
+
+func (s SlackWebhookSink) Flush() error { return nil }
+func (s SlackWebhookSink) Close() error { return nil }
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink forwards entries to the local or remote syslog daemon,
+// mapping severity to the matching syslog priority.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink dials network/raddr (see syslog.Dial; network=="" dials
+// the local syslog daemon) and tags entries with tag.
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (s *SyslogSink) Write(e logEntry) error {
+	line := fmt.Sprintf("%v - %v", e.Tags, e.Message)
+
+	switch e.Severity {
+	case CriticalSeverity:
+		return s.writer.Crit(line)
+	case ErrorSeverity:
+		return s.writer.Err(line)
+	case WarningSeverity:
+		return s.writer.Warning(line)
+	case DebugSeverity:
+		return s.writer.Debug(line)
+	default:
+		return s.writer.Info(line)
+	}
+}
+
+func (s *SyslogSink) Flush() error { return nil }
+func (s *SyslogSink) Close() error { return s.writer.Close() }
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSeverityAllows(t *testing.T) {
+	cases := []struct {
+		sev  Severity
+		min  Severity
+		want bool
+	}{
+		{DebugSeverity, DebugSeverity, true},
+		{DebugSeverity, WarningSeverity, false},
+		{WarningSeverity, DebugSeverity, true},
+		{CriticalSeverity, ErrorSeverity, true},
+		{ErrorSeverity, CriticalSeverity, false},
+	}
+	for _, c := range cases {
+		if got := c.sev.allows(c.min); got != c.want {
+			t.Errorf("%s.allows(%s) = %v, want %v", c.sev, c.min, got, c.want)
+		}
+	}
+}
+
+func TestSeverityRankOrdering(t *testing.T) {
+	order := []Severity{DebugSeverity, InfoSeverity, WarningSeverity, ErrorSeverity, CriticalSeverity}
+	for i := 1; i < len(order); i++ {
+		if severityRank[order[i]] <= severityRank[order[i-1]] {
+			t.Errorf("severityRank[%s] = %d, want it to rank above %s (%d)",
+				order[i], severityRank[order[i]], order[i-1], severityRank[order[i-1]])
+		}
+	}
+}
+
+// errSink always fails, recording whatever it was asked to write.
+type errSink struct {
+	entries []logEntry
+}
+
+func (s *errSink) Write(e logEntry) error {
+	s.entries = append(s.entries, e)
+	return errors.New("boom")
+}
+func (s *errSink) Flush() error { return nil }
+func (s *errSink) Close() error { return nil }
+
+func TestWriteToSinksFiltersBySeverity(t *testing.T) {
+	prev := logr
+	debugSink := &captureSink{}
+	errorSink := &captureSink{}
+	logr = &logger{options: Options{Sinks: []SinkConfig{
+		{Sink: debugSink, Level: DebugSeverity},
+		{Sink: errorSink, Level: ErrorSeverity},
+	}}}
+	t.Cleanup(func() { logr = prev })
+
+	if err := writeToSinks(logEntry{Severity: WarningSeverity, Message: "hi"}); err != nil {
+		t.Fatalf("writeToSinks returned error: %v", err)
+	}
+
+	if len(debugSink.entries) != 1 {
+		t.Errorf("debugSink got %d entries, want 1", len(debugSink.entries))
+	}
+	if len(errorSink.entries) != 0 {
+		t.Errorf("errorSink got %d entries, want 0 (Warning doesn't meet its Error threshold)", len(errorSink.entries))
+	}
+}
+
+func TestWriteToSinksContinuesPastSinkError(t *testing.T) {
+	prev := logr
+	failing := &errSink{}
+	capturing := &captureSink{}
+	logr = &logger{options: Options{Sinks: []SinkConfig{
+		{Sink: failing, Level: DebugSeverity},
+		{Sink: capturing, Level: DebugSeverity},
+	}}}
+	t.Cleanup(func() { logr = prev })
+
+	err := writeToSinks(logEntry{Severity: InfoSeverity, Message: "hi"})
+	if err == nil {
+		t.Fatal("writeToSinks should surface the failing sink's error")
+	}
+	if len(failing.entries) != 1 {
+		t.Errorf("failing sink got %d entries, want 1", len(failing.entries))
+	}
+	if len(capturing.entries) != 1 {
+		t.Error("a sink erroring should not stop later sinks from being written to")
+	}
+}
+
+func TestWriteToSinksRoutesHTTPSinkThroughDispatcher(t *testing.T) {
+	prev := logr
+	d := &dispatcher{buffer: make(chan logEntry, 1)}
+	logr = &logger{
+		options:    Options{Sinks: []SinkConfig{{Sink: HTTPSink{}, Level: DebugSeverity}}},
+		dispatcher: d,
+	}
+	t.Cleanup(func() { logr = prev })
+
+	e := logEntry{Severity: InfoSeverity, Message: "hi"}
+	if err := writeToSinks(e); err != nil {
+		t.Fatalf("writeToSinks returned error: %v", err)
+	}
+
+	select {
+	case got := <-d.buffer:
+		if got.Message != e.Message {
+			t.Errorf("buffered entry = %#v, want %#v", got, e)
+		}
+	default:
+		t.Fatal("HTTPSink should be routed through the dispatcher's buffer, not written synchronously")
+	}
+}